@@ -0,0 +1,156 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultTrendBuckets is how many buckets -trend analyzes when -trend-buckets isn't given.
+const defaultTrendBuckets = 12
+
+// config holds the CLI flags that configure a single run of boots.
+type config struct {
+	owner           string
+	repo            string
+	forge           string
+	forgeURL        string
+	cacheDir        string
+	workers         int
+	rollbackPattern string
+	rollbackWindow  time.Duration
+	issueTracker    string
+	jiraURL         string
+	jiraProject     string
+	output          string
+	outputFile      string
+
+	// since and until bound the analysis window. Either may be zero, in which case main
+	// falls back to its defaults (until: now, since: until-window).
+	since time.Time
+	until time.Time
+
+	// window is how far back from until to look, when since isn't given explicitly.
+	window time.Duration
+
+	// trend, when set, re-runs the analysis over trendBuckets consecutive windows of
+	// trendBucketWindow each, ending at until, instead of a single report.
+	trend             bool
+	trendBuckets      int
+	trendBucketWindow time.Duration
+}
+
+// parseFlags parses the CLI flags and validates the required ones.
+func parseFlags() *config {
+	owner := flag.String("owner", "", "Specify the owner name")
+	repo := flag.String("repo", "", "Specify the repo name")
+	forge := flag.String("forge", forgeGitHub, "Specify the forge to query: github, gitlab, gitea or gerrit. Authenticates from GITHUB_TOKEN, GITLAB_TOKEN, GITEA_TOKEN or GERRIT_USER/GERRIT_PASSWORD respectively")
+	forgeURL := flag.String("forge-url", "", "Base URL of the forge instance, e.g. https://gitlab.example.com. Required for -forge=gerrit and -forge=gitea; defaults to gitlab.com for -forge=gitlab and api.github.com for -forge=github")
+	cacheDir := flag.String("cache-dir", ".boots-cache", "Directory to cache forge API responses in, so unchanged data isn't re-fetched")
+	workers := flag.Int("workers", defaultWorkerCount, "Number of pull requests to fetch and analyze concurrently")
+	rollbackPattern := flag.String("rollback-pattern", `(?i)revert|hotfix`, "Regex matched against a deployment's pull request title/branch to detect a change failure")
+	rollbackWindow := flag.Duration("rollback-window", 24*time.Hour, "How soon after a deployment a matching rollback still counts as a change failure")
+	issueTracker := flag.String("issue-tracker", "", "Issue tracker to resolve tickets from in addition to GitHub closing keywords and the branch-name fallback: jira, linear or shortcut")
+	jiraURL := flag.String("jira-url", "", "Base URL of the Jira instance, used to verify ticket keys found by -issue-tracker=jira")
+	jiraProject := flag.String("jira-project", "", "Restrict Jira key matching to this project prefix, e.g. PROJ")
+	output := flag.String("output", outputText, "Output format: text, json, csv, prom or html")
+	outputFile := flag.String("output-file", "", "File to write the report to (defaults to stdout)")
+	since := flag.String("since", "", "Only consider pull requests merged on or after this date (YYYY-MM-DD). Defaults to -until minus -window")
+	until := flag.String("until", "", "Only consider pull requests merged before this date (YYYY-MM-DD). Defaults to now")
+	window := flag.String("window", "", "How far back to look when -since isn't given, e.g. 14d or 336h. Defaults to two weeks")
+	trend := flag.Bool("trend", false, "Instead of a single report, run the analysis over -trend-buckets consecutive windows of -trend-bucket-window each and report a time series")
+	trendBuckets := flag.Int("trend-buckets", defaultTrendBuckets, "Number of buckets to analyze in -trend mode")
+	trendBucketWindow := flag.String("trend-bucket-window", "7d", "Length of each bucket in -trend mode, e.g. 7d or 168h")
+	flag.Parse()
+
+	if *owner == "" || *repo == "" {
+		log.Fatal("Please specify an owner and repo!")
+	}
+
+	if *forgeURL == "" && (*forge == forgeGerrit || *forge == forgeGitea) {
+		log.Fatalf("-forge-url is required for -forge=%s", *forge)
+	}
+
+	sinceTime, err := parseDateFlag("-since", *since)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	untilTime, err := parseDateFlag("-until", *until)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	windowDuration, err := parseDayDuration(*window)
+	if err != nil {
+		log.Fatal("Invalid -window: ", err)
+	}
+	if windowDuration == 0 {
+		windowDuration = analysisWindow
+	}
+
+	trendBucketWindowDuration, err := parseDayDuration(*trendBucketWindow)
+	if err != nil {
+		log.Fatal("Invalid -trend-bucket-window: ", err)
+	}
+
+	return &config{
+		owner:             *owner,
+		repo:              *repo,
+		forge:             *forge,
+		forgeURL:          *forgeURL,
+		cacheDir:          *cacheDir,
+		workers:           *workers,
+		rollbackPattern:   *rollbackPattern,
+		rollbackWindow:    *rollbackWindow,
+		issueTracker:      *issueTracker,
+		jiraURL:           *jiraURL,
+		jiraProject:       *jiraProject,
+		output:            *output,
+		outputFile:        *outputFile,
+		since:             sinceTime,
+		until:             untilTime,
+		window:            windowDuration,
+		trend:             *trend,
+		trendBuckets:      *trendBuckets,
+		trendBucketWindow: trendBucketWindowDuration,
+	}
+}
+
+// parseDateFlag parses a YYYY-MM-DD flag value. An empty value returns the zero Time, which
+// callers treat as "not set".
+func parseDateFlag(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s %q, expected YYYY-MM-DD: %w", name, value, err)
+	}
+
+	return t, nil
+}
+
+// parseDayDuration parses a duration that additionally accepts a trailing "d" suffix for
+// days, e.g. "14d", since time.ParseDuration has no unit larger than hours. An empty value
+// returns 0, which callers treat as "not set".
+func parseDayDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid day count %q: %w", value, err)
+		}
+
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/montanaflynn/stats"
+)
+
+const deploymentStateSuccess = "success"
+const deploymentStateFailure = "failure"
+
+// defaultRollbackPattern matches PR titles/branches that indicate a deployment was reverted
+// or hotfixed, used as the change-failure signal when the forge has no native rollback
+// status.
+var defaultRollbackPattern = regexp.MustCompile(`(?i)revert|hotfix`)
+
+// DORAMetrics holds the three DORA metrics beyond lead time for changes, computed
+// separately for each deployment environment.
+type DORAMetrics struct {
+	Environment string
+
+	// Successful deployments per day over the analysis window.
+	DeploymentFrequency float64
+
+	// Fraction of deployments followed by a rollback (a failure status, or the next
+	// deployment's pull request looking like a revert/hotfix).
+	ChangeFailureRate float64
+
+	// Median time between a failed deployment and the next successful one.
+	MTTR time.Duration
+}
+
+// computeDORAMetrics groups deployments by environment and derives deployment frequency,
+// change failure rate and MTTR for each.
+func (s *MetricsService) computeDORAMetrics(deployments []*Deployment, prs []*PullRequest) []DORAMetrics {
+	byEnvironment := make(map[string][]*Deployment)
+	for _, d := range deployments {
+		byEnvironment[d.Environment] = append(byEnvironment[d.Environment], d)
+	}
+
+	prByMergeSHA := make(map[string]*PullRequest, len(prs))
+	for _, pr := range prs {
+		prByMergeSHA[pr.MergeCommitSHA] = pr
+	}
+
+	var results []DORAMetrics
+	for environment, envDeployments := range byEnvironment {
+		sort.Slice(envDeployments, func(i, j int) bool {
+			return envDeployments[i].CreatedAt.Before(envDeployments[j].CreatedAt)
+		})
+
+		results = append(results, DORAMetrics{
+			Environment:         environment,
+			DeploymentFrequency: deploymentFrequency(envDeployments, s.window),
+			ChangeFailureRate:   s.changeFailureRate(envDeployments, prByMergeSHA),
+			MTTR:                meanTimeToRecovery(envDeployments),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Environment < results[j].Environment })
+
+	return results
+}
+
+func deploymentFrequency(deployments []*Deployment, window time.Duration) float64 {
+	successCount := 0
+	for _, d := range deployments {
+		if d.State == deploymentStateSuccess {
+			successCount++
+		}
+	}
+
+	days := window.Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+
+	return float64(successCount) / days
+}
+
+// changeFailureRate is the fraction of deployments that were followed by a rollback: either
+// the deployment itself transitioned to failure, or the very next deployment within
+// rollbackWindow has a pull request whose title/branch matches the rollback pattern.
+func (s *MetricsService) changeFailureRate(deployments []*Deployment, prByMergeSHA map[string]*PullRequest) float64 {
+	if len(deployments) == 0 {
+		return 0
+	}
+
+	pattern := s.rollbackPattern
+	if pattern == nil {
+		pattern = defaultRollbackPattern
+	}
+
+	failures := 0
+	for i, d := range deployments {
+		if d.State == deploymentStateFailure {
+			failures++
+			continue
+		}
+
+		if i+1 >= len(deployments) {
+			continue
+		}
+
+		next := deployments[i+1]
+		if next.CreatedAt.Sub(d.CreatedAt) > s.rollbackWindow {
+			continue
+		}
+
+		if pr, ok := prByMergeSHA[next.SHA]; ok && (pattern.MatchString(pr.Title) || pattern.MatchString(pr.HeadRefName)) {
+			failures++
+		}
+	}
+
+	return float64(failures) / float64(len(deployments))
+}
+
+// meanTimeToRecovery is the median time between a failed deployment and the next
+// successful one on the same environment (the DORA metric is conventionally abbreviated
+// MTTR despite being a median, not a mean).
+func meanTimeToRecovery(deployments []*Deployment) time.Duration {
+	var recoveryTimes []float64
+
+	var openFailureAt time.Time
+	for _, d := range deployments {
+		if d.State == deploymentStateFailure {
+			if openFailureAt.IsZero() {
+				openFailureAt = d.CreatedAt
+			}
+			continue
+		}
+
+		if d.State == deploymentStateSuccess && !openFailureAt.IsZero() {
+			recoveryTimes = append(recoveryTimes, d.CreatedAt.Sub(openFailureAt).Hours())
+			openFailureAt = time.Time{}
+		}
+	}
+
+	median, err := stats.Median(recoveryTimes)
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(median * float64(time.Hour))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// maxFetchRetries bounds how many times fetcher.do retries a single call before giving up.
+const maxFetchRetries = 5
+
+// rateLimitBuffer is how many requests we keep in reserve before proactively sleeping
+// until the primary rate limit resets, rather than racing it to zero.
+const rateLimitBuffer = 10
+
+// fetcher wraps outgoing forge requests with QPS limiting and retries with exponential
+// backoff for rate limits and transient server errors, so one slow or rate-limited repo
+// doesn't abort the whole run. It only relies on the standard http.Response every forge
+// client's own response type embeds, so the same fetcher backs GitHub, GitLab, Gitea and
+// Gerrit instead of being wired into one forge alone.
+type fetcher struct {
+	limiter *rate.Limiter
+}
+
+// newFetcher builds a fetcher capped at qps requests per second.
+func newFetcher(qps float64) *fetcher {
+	return &fetcher{limiter: rate.NewLimiter(rate.Limit(qps), 1)}
+}
+
+// do runs call, waiting for the QPS limiter first and retrying on rate limits and 5xx
+// errors with exponential backoff and jitter. It also proactively sleeps until the primary
+// rate limit resets once remaining requests drop below rateLimitBuffer, unless the response
+// was served from the on-disk cache, since a cache hit or successful revalidation never
+// counts against the forge's rate limit in the first place.
+func (f *fetcher) do(ctx context.Context, call func() (*http.Response, error)) error {
+	var lastErr error
+
+	for attempt := 0; attempt < maxFetchRetries; attempt++ {
+		if err := f.limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		resp, err := call()
+
+		if resp != nil && !isFromCache(resp) {
+			if remaining, reset, ok := parseRateLimitHeaders(resp.Header); ok && remaining <= rateLimitBuffer {
+				sleepUntilRateLimitReset(reset)
+			}
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+
+		if !isRetryableFetchError(resp) {
+			return err
+		}
+
+		backoff := backoffWithJitter(attempt)
+		log.Printf("Retrying after transient error (attempt %d/%d): %v", attempt+1, maxFetchRetries, err)
+		time.Sleep(backoff)
+	}
+
+	return lastErr
+}
+
+// isFromCache reports whether resp was served by the httpcache transport instead of the
+// forge itself, via the X-From-Cache header httpcache sets on both cache hits and
+// successfully revalidated conditional requests.
+func isFromCache(resp *http.Response) bool {
+	return resp.Header.Get("X-From-Cache") != ""
+}
+
+// parseRateLimitHeaders reads the GitHub-style X-RateLimit-Remaining/X-RateLimit-Reset
+// headers that GitHub, GitLab and Gitea all expose on their REST APIs. ok is false when the
+// forge doesn't send these headers at all (e.g. Gerrit), in which case callers should skip
+// rate-limit pacing entirely rather than treat a missing header as "0 remaining".
+func parseRateLimitHeaders(h http.Header) (remaining int, reset time.Time, ok bool) {
+	remainingHeader := h.Get("X-RateLimit-Remaining")
+	if remainingHeader == "" {
+		return 0, time.Time{}, false
+	}
+
+	remaining, err := strconv.Atoi(remainingHeader)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+
+	if resetHeader := h.Get("X-RateLimit-Reset"); resetHeader != "" {
+		if resetUnix, err := strconv.ParseInt(resetHeader, 10, 64); err == nil {
+			reset = time.Unix(resetUnix, 0)
+		}
+	}
+
+	return remaining, reset, true
+}
+
+func sleepUntilRateLimitReset(reset time.Time) {
+	wait := time.Until(reset)
+	if wait > 0 {
+		log.Printf("Approaching rate limit, sleeping %s until reset", wait.Round(time.Second))
+		time.Sleep(wait)
+	}
+}
+
+// isRetryableFetchError reports whether a failed call is worth retrying: a secondary rate
+// limit (403), an explicit 429, or a server error. A nil response (a transport-level error
+// such as a cancelled context) is not retried.
+func isRetryableFetchError(resp *http.Response) bool {
+	if resp == nil {
+		return false
+	}
+
+	return resp.StatusCode == http.StatusForbidden ||
+		resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500
+}
+
+func backoffWithJitter(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt)) * time.Second
+	jitter := time.Duration(rand.Int63n(int64(base)))
+	return base + jitter
+}
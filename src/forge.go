@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// PullRequest is a forge-neutral view of a merged (or closed) pull/merge request.
+type PullRequest struct {
+	Number         int
+	Title          string
+	Body           string
+	HeadRefName    string
+	State          string
+	Merged         bool
+	CreatedAt      time.Time
+	MergedAt       time.Time
+	MergeCommitSHA string
+}
+
+// TimelineEvent is a forge-neutral view of an event on a pull request's timeline, e.g. a
+// review request or a submitted review.
+type TimelineEvent struct {
+	Event       string
+	CreatedAt   time.Time
+	SubmittedAt time.Time
+}
+
+// Deployment is a forge-neutral view of a deployment of a specific commit.
+type Deployment struct {
+	SHA         string
+	Environment string
+	State       string
+	CreatedAt   time.Time
+}
+
+// Commit is a forge-neutral view of a single commit on a pull request.
+type Commit struct {
+	SHA        string
+	AuthorDate time.Time
+}
+
+// Forge abstracts over the code-review platform that hosts the repository under study
+// (GitHub, GitLab, Gitea/Forgejo, Gerrit, ...), so MetricsService never has to know which
+// one it's talking to.
+type Forge interface {
+	// ListMergedPullRequests returns merged pull/merge requests closed since the given time,
+	// newest first.
+	ListMergedPullRequests(ctx context.Context, repo *Repository, since time.Time) ([]*PullRequest, error)
+
+	// ListTimelineEvents returns the timeline events for a single pull/merge request, e.g.
+	// review requests and submitted reviews.
+	ListTimelineEvents(ctx context.Context, repo *Repository, pr *PullRequest) ([]*TimelineEvent, error)
+
+	// ListDeployments returns deployments for the repository created in [since, until), so
+	// that DORA metrics are computed over the same window as the pull requests they're
+	// correlated with.
+	ListDeployments(ctx context.Context, repo *Repository, since, until time.Time) ([]*Deployment, error)
+
+	// ListCommits returns the commits that make up a single pull/merge request.
+	ListCommits(ctx context.Context, repo *Repository, pr *PullRequest) ([]*Commit, error)
+}
+
+const (
+	forgeGitHub = "github"
+	forgeGitLab = "gitlab"
+	forgeGitea  = "gitea"
+	forgeGerrit = "gerrit"
+)
+
+// forgeCredentials holds the authentication material newForge needs, resolved per-forge
+// since GitHub, GitLab and Gitea authenticate with a bearer/private token while Gerrit
+// authenticates with a username and HTTP password.
+type forgeCredentials struct {
+	token    string
+	username string
+	password string
+}
+
+// newForge constructs the Forge implementation selected via -forge, wiring it up with an
+// HTTP client for caching and the credentials needed to authenticate against that platform.
+func newForge(ctx context.Context, name string, httpClient *http.Client, baseURL string, creds forgeCredentials) Forge {
+	switch name {
+	case forgeGitLab:
+		return NewGitLabForge(httpClient, baseURL, creds.token)
+	case forgeGitea:
+		return NewGiteaForge(httpClient, baseURL, creds.token)
+	case forgeGerrit:
+		return NewGerritForge(ctx, httpClient, baseURL, creds.username, creds.password)
+	case forgeGitHub, "":
+		// GitHub has no client-side token setter; httpClient is expected to already carry
+		// the bearer-token transport setupHttpClient built for it.
+		return NewGitHubForge(github.NewClient(httpClient))
+	default:
+		log.Fatalf("Unknown forge %q, expected one of: github, gitlab, gitea, gerrit", name)
+		return nil
+	}
+}
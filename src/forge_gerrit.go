@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/andygrunwald/go-gerrit"
+)
+
+// codeReviewLabel is the label Gerrit uses for the review scores ("Code-Review +2" is what
+// we treat as an approving review).
+const codeReviewLabel = "Code-Review"
+
+// gerritApprovalDateLayout is the format Gerrit's REST API returns ApprovalInfo.Date in: UTC,
+// space-separated, nanosecond precision, no "T" and no offset (unlike the Timestamp type used
+// for most other dates in the API, which go-gerrit parses for us).
+const gerritApprovalDateLayout = "2006-01-02 15:04:05.000000000"
+
+// defaultGerritQPS caps outgoing requests at a conservative rate: Gerrit's REST API doesn't
+// advertise a rate limit or send rate-limit headers, so there's nothing to adapt to.
+const defaultGerritQPS = 5
+
+// GerritForge implements Forge on top of the Gerrit REST API. Gerrit has no native concept
+// of a "pull request" or a "deployment": merged changes stand in for merged pull requests,
+// Code-Review +2 votes stand in for reviews, and tagged releases stand in for deployments.
+type GerritForge struct {
+	client  *gerrit.Client
+	fetcher *fetcher
+}
+
+// NewGerritForge builds a Forge backed by a Gerrit instance at baseURL. Unlike the other
+// forges, Gerrit has no concept of a bearer token: username/password (the account's HTTP
+// password, generated from the Gerrit web UI) are set directly on the client and Gerrit
+// negotiates Basic or Digest auth for itself on the first request.
+func NewGerritForge(ctx context.Context, httpClient *http.Client, baseURL, username, password string) *GerritForge {
+	client, err := gerrit.NewClient(ctx, baseURL, httpClient)
+	if err != nil {
+		panic(err)
+	}
+
+	if username != "" {
+		client.Authentication.SetBasicAuth(username, password)
+	}
+
+	return &GerritForge{client: client, fetcher: newFetcher(defaultGerritQPS)}
+}
+
+// rawResponse extracts the underlying *http.Response that fetcher needs, handling a nil
+// *gerrit.Response (e.g. a transport-level error) without panicking on the embedded field.
+func rawGerritResponse(resp *gerrit.Response) *http.Response {
+	if resp == nil {
+		return nil
+	}
+
+	return resp.Response
+}
+
+func (f *GerritForge) ListMergedPullRequests(ctx context.Context, repo *Repository, since time.Time) ([]*PullRequest, error) {
+	query := "project:" + repo.name + " status:merged"
+
+	opt := &gerrit.QueryChangeOptions{
+		QueryOptions: gerrit.QueryOptions{Query: []string{query}, Limit: 10},
+		ChangeOptions: gerrit.ChangeOptions{
+			AdditionalFields: []string{"CURRENT_REVISION", "DETAILED_LABELS"},
+		},
+	}
+
+	var allChanges []*PullRequest
+
+	for {
+		var changes *[]gerrit.ChangeInfo
+
+		err := f.fetcher.do(ctx, func() (*http.Response, error) {
+			var resp *gerrit.Response
+			var err error
+			changes, resp, err = f.client.Changes.QueryChanges(ctx, opt)
+			return rawGerritResponse(resp), err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if changes == nil || len(*changes) == 0 {
+			break
+		}
+
+		done := false
+		for _, c := range *changes {
+			mergedAt := c.Submitted.Time
+			if mergedAt.Before(since) {
+				done = true
+				break
+			}
+
+			allChanges = append(allChanges, convertGerritChange(&c, mergedAt))
+		}
+
+		if done || len(*changes) < opt.Limit {
+			break
+		}
+
+		opt.Start += opt.Limit
+	}
+
+	return allChanges, nil
+}
+
+func (f *GerritForge) ListTimelineEvents(ctx context.Context, repo *Repository, pr *PullRequest) ([]*TimelineEvent, error) {
+	var detail *gerrit.ChangeInfo
+
+	err := f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *gerrit.Response
+		var err error
+		detail, resp, err = f.client.Changes.GetReview(ctx, pr.HeadRefName, "current")
+		return rawGerritResponse(resp), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only Code-Review votes are reviews. detail.Labels also carries Verified, CI and any
+	// other custom labels the project defines; ranging over all of them (detail.Labels is a
+	// map, so iteration order is random besides) would misclassify those as review events.
+	var allEvents []*TimelineEvent
+	for _, approval := range detail.Labels[codeReviewLabel].All {
+		event := eventReviewRequested
+		if approval.Value >= 2 {
+			event = eventReviewed
+		}
+
+		submittedAt, err := time.Parse(gerritApprovalDateLayout, approval.Date)
+		if err != nil {
+			continue
+		}
+
+		allEvents = append(allEvents, &TimelineEvent{
+			Event:       event,
+			SubmittedAt: submittedAt,
+		})
+	}
+
+	return allEvents, nil
+}
+
+// ListDeployments has no direct Gerrit equivalent, so we treat tagged releases on the
+// project as deployments, consistent with the repo's fallback for forges without a native
+// deployment concept.
+func (f *GerritForge) ListDeployments(ctx context.Context, repo *Repository, since, until time.Time) ([]*Deployment, error) {
+	var tags *[]gerrit.TagInfo
+
+	err := f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *gerrit.Response
+		var err error
+		tags, resp, err = f.client.Projects.ListTags(ctx, repo.name, &gerrit.ProjectBaseOptions{})
+		return rawGerritResponse(resp), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The Gerrit tags API has no server-side time filter, so the window is applied here.
+	result := make([]*Deployment, 0, len(*tags))
+	for _, t := range *tags {
+		createdAt := t.Created.Time
+		if createdAt.Before(since) || !createdAt.Before(until) {
+			continue
+		}
+
+		result = append(result, &Deployment{
+			SHA:       t.Revision,
+			State:     "success",
+			CreatedAt: createdAt,
+		})
+	}
+
+	return result, nil
+}
+
+// ListCommits returns the single commit backing the change's current revision. Gerrit
+// changes are one commit per revision, not a branch of commits like a GitHub pull request,
+// so there's no equivalent of listing multiple commits for a change.
+func (f *GerritForge) ListCommits(ctx context.Context, repo *Repository, pr *PullRequest) ([]*Commit, error) {
+	var commit *gerrit.CommitInfo
+
+	err := f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *gerrit.Response
+		var err error
+		commit, resp, err = f.client.Changes.GetCommit(ctx, pr.HeadRefName, "current", nil)
+		return rawGerritResponse(resp), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []*Commit{{SHA: commit.Commit, AuthorDate: commit.Author.Date.Time}}, nil
+}
+
+func convertGerritChange(c *gerrit.ChangeInfo, mergedAt time.Time) *PullRequest {
+	return &PullRequest{
+		Number:         c.Number,
+		Title:          c.Subject,
+		HeadRefName:    c.ID,
+		State:          c.Status,
+		Merged:         c.Status == "MERGED",
+		CreatedAt:      c.Created.Time,
+		MergedAt:       mergedAt,
+		MergeCommitSHA: c.CurrentRevision,
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// giteaCommitDateLayout is the format code.gitea.io/sdk/gitea returns commit author/committer
+// dates in (CommitUser.Date is a plain string, not a time.Time).
+const giteaCommitDateLayout = time.RFC3339
+
+// defaultGiteaQPS caps outgoing requests at a conservative rate, since self-hosted Gitea/
+// Forgejo instances rarely advertise a documented rate limit to tune against.
+const defaultGiteaQPS = 5
+
+// GiteaForge implements Forge on top of the Gitea/Forgejo REST API.
+type GiteaForge struct {
+	client  *gitea.Client
+	fetcher *fetcher
+}
+
+// NewGiteaForge builds a Forge backed by a Gitea or Forgejo instance at baseURL,
+// authenticating with token (GITEA_TOKEN). httpClient carries caching, not authentication.
+func NewGiteaForge(httpClient *http.Client, baseURL, token string) *GiteaForge {
+	client, err := gitea.NewClient(baseURL, gitea.SetHTTPClient(httpClient), gitea.SetToken(token))
+	if err != nil {
+		panic(err)
+	}
+
+	return &GiteaForge{client: client, fetcher: newFetcher(defaultGiteaQPS)}
+}
+
+// rawResponse extracts the underlying *http.Response that fetcher needs, handling a nil
+// *gitea.Response (e.g. a transport-level error) without panicking on the embedded field.
+func rawGiteaResponse(resp *gitea.Response) *http.Response {
+	if resp == nil {
+		return nil
+	}
+
+	return resp.Response
+}
+
+func (f *GiteaForge) ListMergedPullRequests(ctx context.Context, repo *Repository, since time.Time) ([]*PullRequest, error) {
+	var allPullRequests []*PullRequest
+
+	opt := gitea.ListPullRequestsOptions{
+		ListOptions: gitea.ListOptions{PageSize: 10},
+		State:       gitea.StateClosed,
+	}
+
+	for {
+		var pullRequests []*gitea.PullRequest
+		var resp *gitea.Response
+
+		err := f.fetcher.do(ctx, func() (*http.Response, error) {
+			var err error
+			pullRequests, resp, err = f.client.ListRepoPullRequests(repo.owner, repo.name, opt)
+			return rawGiteaResponse(resp), err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		done := false
+		for _, p := range pullRequests {
+			if p.Merged == nil {
+				continue
+			}
+			if p.Merged.Before(since) {
+				done = true
+				break
+			}
+
+			allPullRequests = append(allPullRequests, convertGiteaPullRequest(p))
+		}
+
+		if done || resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allPullRequests, nil
+}
+
+func (f *GiteaForge) ListTimelineEvents(ctx context.Context, repo *Repository, pr *PullRequest) ([]*TimelineEvent, error) {
+	var reviews []*gitea.PullReview
+
+	err := f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *gitea.Response
+		var err error
+		reviews, resp, err = f.client.ListPullReviews(repo.owner, repo.name, int64(pr.Number), gitea.ListPullReviewsOptions{})
+		return rawGiteaResponse(resp), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allEvents := make([]*TimelineEvent, 0, len(reviews))
+	for _, r := range reviews {
+		allEvents = append(allEvents, &TimelineEvent{
+			Event:       eventReviewed,
+			SubmittedAt: r.Submitted,
+		})
+	}
+
+	return allEvents, nil
+}
+
+func (f *GiteaForge) ListDeployments(ctx context.Context, repo *Repository, since, until time.Time) ([]*Deployment, error) {
+	var result []*Deployment
+
+	opt := gitea.ListReleasesOptions{ListOptions: gitea.ListOptions{PageSize: 10}}
+
+	for {
+		var releases []*gitea.Release
+		var resp *gitea.Response
+
+		err := f.fetcher.do(ctx, func() (*http.Response, error) {
+			var err error
+			releases, resp, err = f.client.ListReleases(repo.owner, repo.name, opt)
+			return rawGiteaResponse(resp), err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range releases {
+			if r.CreatedAt.Before(since) || !r.CreatedAt.Before(until) {
+				continue
+			}
+
+			result = append(result, &Deployment{
+				// Target is the commit the tag was cut from; TagName is just the tag's
+				// display name and never matches a pull request's MergeCommitSHA.
+				SHA:       r.Target,
+				State:     "success",
+				CreatedAt: r.CreatedAt,
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return result, nil
+}
+
+func (f *GiteaForge) ListCommits(ctx context.Context, repo *Repository, pr *PullRequest) ([]*Commit, error) {
+	var commits []*gitea.Commit
+
+	err := f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *gitea.Response
+		var err error
+		commits, resp, err = f.client.ListPullRequestCommits(repo.owner, repo.name, int64(pr.Number), gitea.ListPullRequestCommitsOptions{})
+		return rawGiteaResponse(resp), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Commit, 0, len(commits))
+	for _, c := range commits {
+		if c.RepoCommit == nil || c.RepoCommit.Author == nil {
+			continue
+		}
+
+		authorDate, err := time.Parse(giteaCommitDateLayout, c.RepoCommit.Author.Date)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, &Commit{SHA: c.SHA, AuthorDate: authorDate})
+	}
+
+	return result, nil
+}
+
+func convertGiteaPullRequest(p *gitea.PullRequest) *PullRequest {
+	var mergedAt time.Time
+	if p.Merged != nil {
+		mergedAt = *p.Merged
+	}
+
+	headRef := ""
+	if p.Head != nil {
+		headRef = p.Head.Ref
+	}
+
+	mergeSHA := ""
+	if p.MergedCommitID != nil {
+		mergeSHA = *p.MergedCommitID
+	}
+
+	return &PullRequest{
+		Number:         int(p.Index),
+		Title:          p.Title,
+		Body:           p.Body,
+		HeadRefName:    headRef,
+		State:          string(p.State),
+		Merged:         p.HasMerged,
+		CreatedAt:      *p.Created,
+		MergedAt:       mergedAt,
+		MergeCommitSHA: mergeSHA,
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v52/github"
+)
+
+// defaultGitHubQPS caps outgoing requests well under the primary rate limit (5000/hr ≈
+// 1.4/s), leaving headroom for other tools hitting the same token.
+const defaultGitHubQPS = 5
+
+// GitHubForge implements Forge on top of the GitHub REST API.
+type GitHubForge struct {
+	client  *github.Client
+	fetcher *fetcher
+}
+
+// NewGitHubForge wraps an already-configured GitHub client as a Forge.
+func NewGitHubForge(client *github.Client) *GitHubForge {
+	return &GitHubForge{client: client, fetcher: newFetcher(defaultGitHubQPS)}
+}
+
+// rawResponse extracts the underlying *http.Response that fetcher needs, handling a nil
+// *github.Response (e.g. a transport-level error) without panicking on the embedded field.
+func rawResponse(resp *github.Response) *http.Response {
+	if resp == nil {
+		return nil
+	}
+
+	return resp.Response
+}
+
+func (f *GitHubForge) ListMergedPullRequests(ctx context.Context, repo *Repository, since time.Time) ([]*PullRequest, error) {
+	var allPullRequests []*PullRequest
+
+	opt := &github.PullRequestListOptions{
+		State:       "closed",
+		ListOptions: github.ListOptions{PerPage: 10},
+	}
+
+	for {
+		var pullRequests []*github.PullRequest
+		var resp *github.Response
+
+		err := f.fetcher.do(ctx, func() (*http.Response, error) {
+			var err error
+			pullRequests, resp, err = f.client.PullRequests.List(ctx, repo.owner, repo.name, opt)
+			return rawResponse(resp), err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		done := false
+		for _, p := range pullRequests {
+			if p.GetMergedAt().IsZero() {
+				continue
+			}
+			if p.MergedAt.Time.Before(since) {
+				done = true
+				break
+			}
+
+			allPullRequests = append(allPullRequests, convertGitHubPullRequest(p))
+		}
+
+		if done || resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allPullRequests, nil
+}
+
+func (f *GitHubForge) ListTimelineEvents(ctx context.Context, repo *Repository, pr *PullRequest) ([]*TimelineEvent, error) {
+	var allEvents []*TimelineEvent
+
+	opt := &github.ListOptions{PerPage: 10}
+
+	for {
+		var timeline []*github.Timeline
+		var resp *github.Response
+
+		err := f.fetcher.do(ctx, func() (*http.Response, error) {
+			var err error
+			timeline, resp, err = f.client.Issues.ListIssueTimeline(ctx, repo.owner, repo.name, pr.Number, opt)
+			return rawResponse(resp), err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range timeline {
+			allEvents = append(allEvents, &TimelineEvent{
+				Event:       e.GetEvent(),
+				CreatedAt:   e.GetCreatedAt().Time,
+				SubmittedAt: e.GetSubmittedAt().Time,
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allEvents, nil
+}
+
+func (f *GitHubForge) ListDeployments(ctx context.Context, repo *Repository, since, until time.Time) ([]*Deployment, error) {
+	var result []*Deployment
+
+	opt := &github.DeploymentsListOptions{ListOptions: github.ListOptions{PerPage: 10}}
+
+	for {
+		var deployments []*github.Deployment
+		var resp *github.Response
+
+		err := f.fetcher.do(ctx, func() (*http.Response, error) {
+			var err error
+			deployments, resp, err = f.client.Repositories.ListDeployments(ctx, repo.owner, repo.name, opt)
+			return rawResponse(resp), err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		done := false
+		for _, d := range deployments {
+			createdAt := d.GetCreatedAt().Time
+			if createdAt.Before(since) {
+				done = true
+				break
+			}
+			if !createdAt.Before(until) {
+				continue
+			}
+
+			state, err := f.latestDeploymentState(ctx, repo, d.GetID())
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, &Deployment{
+				SHA:         d.GetSHA(),
+				Environment: d.GetEnvironment(),
+				State:       state,
+				CreatedAt:   createdAt,
+			})
+		}
+
+		if done || resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return result, nil
+}
+
+// latestDeploymentState returns the state of the most recent status posted for a
+// deployment, e.g. "success" or "failure". Deployments without any status are reported as
+// "pending", GitHub's own default.
+func (f *GitHubForge) latestDeploymentState(ctx context.Context, repo *Repository, deploymentID int64) (string, error) {
+	var statuses []*github.DeploymentStatus
+
+	err := f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *github.Response
+		var err error
+		statuses, resp, err = f.client.Repositories.ListDeploymentStatuses(ctx, repo.owner, repo.name, deploymentID, &github.ListOptions{PerPage: 1})
+		return rawResponse(resp), err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(statuses) == 0 {
+		return "pending", nil
+	}
+
+	return statuses[0].GetState(), nil
+}
+
+func (f *GitHubForge) ListCommits(ctx context.Context, repo *Repository, pr *PullRequest) ([]*Commit, error) {
+	var commits []*github.RepositoryCommit
+
+	err := f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *github.Response
+		var err error
+		commits, resp, err = f.client.PullRequests.ListCommits(ctx, repo.owner, repo.name, pr.Number, &github.ListOptions{})
+		return rawResponse(resp), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Commit, 0, len(commits))
+	for _, c := range commits {
+		result = append(result, &Commit{
+			SHA:        c.GetSHA(),
+			AuthorDate: c.GetCommit().GetAuthor().GetDate().Time,
+		})
+	}
+
+	return result, nil
+}
+
+func convertGitHubPullRequest(p *github.PullRequest) *PullRequest {
+	headRef := ""
+	if p.Head != nil {
+		headRef = p.Head.GetLabel()
+	}
+
+	return &PullRequest{
+		Number:         p.GetNumber(),
+		Title:          p.GetTitle(),
+		Body:           p.GetBody(),
+		HeadRefName:    headRef,
+		State:          p.GetState(),
+		Merged:         p.GetMerged(),
+		CreatedAt:      p.GetCreatedAt().Time,
+		MergedAt:       p.GetMergedAt().Time,
+		MergeCommitSHA: p.GetMergeCommitSHA(),
+	}
+}
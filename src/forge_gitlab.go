@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/xanzy/go-gitlab"
+)
+
+// defaultGitLabQPS caps outgoing requests well under GitLab.com's default per-user rate
+// limit, leaving headroom for other tools hitting the same token.
+const defaultGitLabQPS = 5
+
+// GitLabForge implements Forge on top of the GitLab REST API, mapping merge requests and
+// their notes onto the same shapes as the GitHub forge.
+type GitLabForge struct {
+	client  *gitlab.Client
+	fetcher *fetcher
+}
+
+// NewGitLabForge builds a Forge backed by a GitLab instance at baseURL (pass "" for
+// gitlab.com), authenticating with token as a private token (GITLAB_TOKEN). httpClient
+// carries caching, not authentication: go-gitlab sets the PRIVATE-TOKEN header itself.
+func NewGitLabForge(httpClient *http.Client, baseURL, token string) *GitLabForge {
+	opts := []gitlab.ClientOptionFunc{gitlab.WithHTTPClient(httpClient)}
+	if baseURL != "" {
+		opts = append(opts, gitlab.WithBaseURL(baseURL))
+	}
+
+	client, err := gitlab.NewClient(token, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return &GitLabForge{client: client, fetcher: newFetcher(defaultGitLabQPS)}
+}
+
+// rawResponse extracts the underlying *http.Response that fetcher needs, handling a nil
+// *gitlab.Response (e.g. a transport-level error) without panicking on the embedded field.
+func rawGitLabResponse(resp *gitlab.Response) *http.Response {
+	if resp == nil {
+		return nil
+	}
+
+	return resp.Response
+}
+
+func (f *GitLabForge) ListMergedPullRequests(ctx context.Context, repo *Repository, since time.Time) ([]*PullRequest, error) {
+	var allMergeRequests []*PullRequest
+
+	state := "merged"
+	opt := &gitlab.ListProjectMergeRequestsOptions{
+		State:       &state,
+		ListOptions: gitlab.ListOptions{PerPage: 10},
+	}
+
+	project := repo.owner + "/" + repo.name
+
+	for {
+		var mergeRequests []*gitlab.MergeRequest
+		var resp *gitlab.Response
+
+		err := f.fetcher.do(ctx, func() (*http.Response, error) {
+			var err error
+			mergeRequests, resp, err = f.client.MergeRequests.ListProjectMergeRequests(project, opt, gitlab.WithContext(ctx))
+			return rawGitLabResponse(resp), err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		done := false
+		for _, mr := range mergeRequests {
+			if mr.MergedAt == nil {
+				continue
+			}
+			if mr.MergedAt.Before(since) {
+				done = true
+				break
+			}
+
+			allMergeRequests = append(allMergeRequests, convertGitLabMergeRequest(mr))
+		}
+
+		if done || resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return allMergeRequests, nil
+}
+
+func (f *GitLabForge) ListTimelineEvents(ctx context.Context, repo *Repository, pr *PullRequest) ([]*TimelineEvent, error) {
+	project := repo.owner + "/" + repo.name
+
+	var allEvents []*TimelineEvent
+	var notes []*gitlab.Note
+
+	err := f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *gitlab.Response
+		var err error
+		notes, resp, err = f.client.Notes.ListMergeRequestNotes(project, pr.Number, &gitlab.ListMergeRequestNotesOptions{}, gitlab.WithContext(ctx))
+		return rawGitLabResponse(resp), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range notes {
+		if n.System && isGitLabReviewRequestNote(n.Body) {
+			allEvents = append(allEvents, &TimelineEvent{Event: eventReviewRequested, CreatedAt: *n.CreatedAt})
+		}
+	}
+
+	var approvals *gitlab.MergeRequestApprovalState
+	err = f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *gitlab.Response
+		var err error
+		approvals, resp, err = f.client.MergeRequestApprovals.GetApprovalState(project, pr.Number, gitlab.WithContext(ctx))
+		return rawGitLabResponse(resp), err
+	})
+	if err == nil && approvals != nil {
+		for _, rule := range approvals.Rules {
+			for _, approver := range rule.ApprovedBy {
+				_ = approver
+				allEvents = append(allEvents, &TimelineEvent{Event: eventReviewed, SubmittedAt: pr.MergedAt})
+			}
+		}
+	}
+
+	return allEvents, nil
+}
+
+func (f *GitLabForge) ListDeployments(ctx context.Context, repo *Repository, since, until time.Time) ([]*Deployment, error) {
+	project := repo.owner + "/" + repo.name
+
+	var result []*Deployment
+
+	opt := &gitlab.ListProjectDeploymentsOptions{
+		ListOptions:  gitlab.ListOptions{PerPage: 10},
+		UpdatedAfter: &since,
+	}
+
+	for {
+		var deployments []*gitlab.Deployment
+		var resp *gitlab.Response
+
+		err := f.fetcher.do(ctx, func() (*http.Response, error) {
+			var err error
+			deployments, resp, err = f.client.Deployments.ListProjectDeployments(project, opt, gitlab.WithContext(ctx))
+			return rawGitLabResponse(resp), err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range deployments {
+			if d.CreatedAt == nil || d.CreatedAt.Before(since) || !d.CreatedAt.Before(until) {
+				continue
+			}
+
+			result = append(result, &Deployment{
+				SHA:         d.SHA,
+				Environment: d.Environment.Name,
+				State:       d.Status,
+				CreatedAt:   *d.CreatedAt,
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opt.Page = resp.NextPage
+	}
+
+	return result, nil
+}
+
+func (f *GitLabForge) ListCommits(ctx context.Context, repo *Repository, pr *PullRequest) ([]*Commit, error) {
+	project := repo.owner + "/" + repo.name
+
+	var commits []*gitlab.Commit
+
+	err := f.fetcher.do(ctx, func() (*http.Response, error) {
+		var resp *gitlab.Response
+		var err error
+		commits, resp, err = f.client.MergeRequests.GetMergeRequestCommits(project, pr.Number, &gitlab.GetMergeRequestCommitsOptions{}, gitlab.WithContext(ctx))
+		return rawGitLabResponse(resp), err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Commit, 0, len(commits))
+	for _, c := range commits {
+		result = append(result, &Commit{SHA: c.ID, AuthorDate: *c.AuthoredDate})
+	}
+
+	return result, nil
+}
+
+func convertGitLabMergeRequest(mr *gitlab.MergeRequest) *PullRequest {
+	var mergedAt time.Time
+	if mr.MergedAt != nil {
+		mergedAt = *mr.MergedAt
+	}
+
+	return &PullRequest{
+		Number:         mr.IID,
+		Title:          mr.Title,
+		Body:           mr.Description,
+		HeadRefName:    mr.SourceBranch,
+		State:          mr.State,
+		Merged:         mr.State == "merged",
+		CreatedAt:      *mr.CreatedAt,
+		MergedAt:       mergedAt,
+		MergeCommitSHA: mr.MergeCommitSHA,
+	}
+}
+
+// isGitLabReviewRequestNote recognizes the system note GitLab adds when a reviewer is
+// requested on a merge request, e.g. "requested review from @alice".
+func isGitLabReviewRequestNote(body string) bool {
+	return len(body) > len("requested review") && body[:len("requested review")] == "requested review"
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// branchPrefixNoIssue is the branch-name marker used by the fallback heuristic to opt a
+// pull request out of issue tracking entirely.
+const branchPrefixNoIssue = "noticket"
+
+// IssueLinker resolves a pull request to an external ticket ID, e.g. a Jira key or a
+// GitHub issue number. Resolve returns ok=false when the pull request can't be linked to
+// anything by this strategy.
+type IssueLinker interface {
+	Resolve(ctx context.Context, pr *PullRequest) (ticketID string, ok bool)
+}
+
+// githubClosesLinker recognizes GitHub's own closing keywords ("closes #12", "fixes GH-7")
+// in a pull request's body.
+type githubClosesLinker struct {
+	pattern *regexp.Regexp
+}
+
+func newGitHubClosesLinker() *githubClosesLinker {
+	return &githubClosesLinker{
+		pattern: regexp.MustCompile(`(?i)\b(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)\s*:?\s*(?:gh-|#)(\d+)\b`),
+	}
+}
+
+func (l *githubClosesLinker) Resolve(ctx context.Context, pr *PullRequest) (string, bool) {
+	match := l.pattern.FindStringSubmatch(pr.Body)
+	if match == nil {
+		return "", false
+	}
+
+	return "#" + match[1], true
+}
+
+// jiraLinker recognizes Jira issue keys (e.g. PROJ-1234) in a pull request's title, branch
+// or body, optionally confined to a single project and optionally verified against a Jira
+// instance.
+type jiraLinker struct {
+	pattern    *regexp.Regexp
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// newJiraLinker builds a jiraLinker. If project is empty, any Jira-shaped key matches. If
+// baseURL is empty, matches are trusted without a REST lookup.
+func newJiraLinker(project, baseURL, token string, httpClient *http.Client) *jiraLinker {
+	projectPattern := `[A-Z][A-Z0-9]+`
+	if project != "" {
+		projectPattern = regexp.QuoteMeta(strings.ToUpper(project))
+	}
+
+	return &jiraLinker{
+		pattern:    regexp.MustCompile(`\b` + projectPattern + `-\d+\b`),
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+func (l *jiraLinker) Resolve(ctx context.Context, pr *PullRequest) (string, bool) {
+	key := l.findKey(pr)
+	if key == "" {
+		return "", false
+	}
+
+	if l.baseURL == "" {
+		return key, true
+	}
+
+	if !l.existsInJira(ctx, key) {
+		return "", false
+	}
+
+	return key, true
+}
+
+func (l *jiraLinker) findKey(pr *PullRequest) string {
+	for _, haystack := range []string{pr.Title, pr.HeadRefName, pr.Body} {
+		if key := l.pattern.FindString(haystack); key != "" {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// existsInJira does a best-effort REST lookup to confirm the ticket is real; any request
+// error is treated as "can't verify, but don't block on it" and the match is trusted.
+func (l *jiraLinker) existsInJira(ctx context.Context, key string) bool {
+	url := fmt.Sprintf("%s/rest/api/2/issue/%s", strings.TrimSuffix(l.baseURL, "/"), key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return true
+	}
+	if l.token != "" {
+		req.Header.Set("Authorization", "Bearer "+l.token)
+	}
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return true
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode != http.StatusNotFound
+}
+
+// linearLinker recognizes Linear issue identifiers, e.g. "ENG-123".
+type linearLinker struct {
+	pattern *regexp.Regexp
+}
+
+func newLinearLinker() *linearLinker {
+	return &linearLinker{pattern: regexp.MustCompile(`\b[A-Z]{2,5}-\d+\b`)}
+}
+
+func (l *linearLinker) Resolve(ctx context.Context, pr *PullRequest) (string, bool) {
+	for _, haystack := range []string{pr.Title, pr.HeadRefName, pr.Body} {
+		if key := l.pattern.FindString(haystack); key != "" {
+			return key, true
+		}
+	}
+
+	return "", false
+}
+
+// shortcutLinker recognizes Shortcut (formerly Clubhouse) story references, e.g. "sc-123"
+// or "ch123".
+type shortcutLinker struct {
+	pattern *regexp.Regexp
+}
+
+func newShortcutLinker() *shortcutLinker {
+	return &shortcutLinker{pattern: regexp.MustCompile(`(?i)\b(?:sc-|ch)(\d+)\b`)}
+}
+
+func (l *shortcutLinker) Resolve(ctx context.Context, pr *PullRequest) (string, bool) {
+	for _, haystack := range []string{pr.Title, pr.HeadRefName, pr.Body} {
+		if match := l.pattern.FindStringSubmatch(haystack); match != nil {
+			return "sc-" + match[1], true
+		}
+	}
+
+	return "", false
+}
+
+// branchPrefixLinker is the original heuristic: a pull request is considered tracked
+// unless its branch is explicitly opted out via the "noticket" marker. It never resolves
+// an actual ticket ID, only whether one should be expected.
+type branchPrefixLinker struct{}
+
+func (branchPrefixLinker) Resolve(ctx context.Context, pr *PullRequest) (string, bool) {
+	if strings.Contains(pr.HeadRefName, branchPrefixNoIssue) {
+		return "", false
+	}
+
+	return "", true
+}
+
+// compositeIssueLinker tries each strategy in order and uses the first one that resolves a
+// ticket ID, falling back to a final strategy that only reports whether the pull request
+// should be considered tracked at all.
+type compositeIssueLinker struct {
+	strategies []IssueLinker
+	fallback   IssueLinker
+}
+
+func (c *compositeIssueLinker) Resolve(ctx context.Context, pr *PullRequest) (string, bool) {
+	for _, strategy := range c.strategies {
+		if ticketID, ok := strategy.Resolve(ctx, pr); ok {
+			return ticketID, true
+		}
+	}
+
+	return c.fallback.Resolve(ctx, pr)
+}
+
+const (
+	issueTrackerJira     = "jira"
+	issueTrackerLinear   = "linear"
+	issueTrackerShortcut = "shortcut"
+)
+
+// newIssueLinker builds the IssueLinker chain for a run: GitHub's own closing keywords are
+// always checked first since they're free, then the configured tracker-specific strategy,
+// falling back to the branch-name heuristic when nothing else matches.
+//
+// Tracker-specific strategies that make their own HTTP calls (e.g. jiraLinker) get a fresh,
+// unauthenticated client of their own rather than the forge's authenticated one: the forge
+// client's oauth2.Transport unconditionally overwrites the Authorization header with the
+// forge token, so reusing it would both leak that token to the tracker host and silently
+// drop the tracker's own credentials.
+func newIssueLinker(cfg *config) IssueLinker {
+	strategies := []IssueLinker{newGitHubClosesLinker()}
+
+	switch cfg.issueTracker {
+	case issueTrackerJira:
+		strategies = append(strategies, newJiraLinker(cfg.jiraProject, cfg.jiraURL, os.Getenv("JIRA_TOKEN"), &http.Client{}))
+	case issueTrackerLinear:
+		strategies = append(strategies, newLinearLinker())
+	case issueTrackerShortcut:
+		strategies = append(strategies, newShortcutLinker())
+	}
+
+	return &compositeIssueLinker{strategies: strategies, fallback: branchPrefixLinker{}}
+}
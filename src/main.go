@@ -2,21 +2,40 @@ package main
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"regexp"
 	"time"
 
-	"github.com/google/go-github/v52/github"
+	"github.com/gregjones/httpcache"
+	"github.com/gregjones/httpcache/diskcache"
 	"github.com/joho/godotenv"
 	"golang.org/x/oauth2"
 )
 
+// analysisWindow is the length of history boots looks at.
+const analysisWindow = 2 * 7 * 24 * time.Hour
+
 type service struct {
-	ghClient   *github.Client
+	forge      Forge
 	repository *Repository
+	workers    int
+
+	// since and until bound the analysis window. window is their difference, used as the
+	// denominator for deployment frequency; it's also used, alongside since/until, to fetch
+	// only the deployments that fall inside the same window.
+	since, until time.Time
+	window       time.Duration
+
+	// rollbackPattern and rollbackWindow configure change failure rate detection: a
+	// deployment counts as a failure if the next deployment within rollbackWindow looks
+	// like a revert/hotfix of it.
+	rollbackPattern *regexp.Regexp
+	rollbackWindow  time.Duration
+
+	issueLinker IssueLinker
 }
 
 type Repository struct {
@@ -24,100 +43,165 @@ type Repository struct {
 	name  string
 }
 
-func setupHttpClient() *http.Client {
-	token := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: os.Getenv("GITHUB_TOKEN")})
-	client := oauth2.NewClient(context.Background(), token)
-	return client
+// setupHttpClient builds an HTTP client that caches responses on disk under cacheDir, so
+// that unchanged data is served from a conditional request (ETag/Last-Modified) instead of
+// counting against the rate limit. For GitHub, authentication also has to live on this
+// client, since go-github expects an already-authenticated http.Client; the other forges'
+// client libraries attach their own auth per forgeCredentials instead (see newForge).
+func setupHttpClient(forge, cacheDir string) *http.Client {
+	var transport http.RoundTripper = http.DefaultTransport
+
+	if forge == forgeGitHub || forge == "" {
+		token := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: os.Getenv("GITHUB_TOKEN")})
+		transport = oauth2.NewClient(context.Background(), token).Transport
+	}
+
+	cacheTransport := httpcache.NewTransport(diskcache.New(cacheDir))
+	cacheTransport.Transport = transport
+
+	return cacheTransport.Client()
+}
+
+// forgeEnvCredentials resolves the environment variables that authenticate against the
+// selected forge: a bearer/private token for GitHub, GitLab and Gitea, or a username and
+// HTTP password for Gerrit.
+func forgeEnvCredentials(forge string) forgeCredentials {
+	switch forge {
+	case forgeGitLab:
+		return forgeCredentials{token: os.Getenv("GITLAB_TOKEN")}
+	case forgeGitea:
+		return forgeCredentials{token: os.Getenv("GITEA_TOKEN")}
+	case forgeGerrit:
+		return forgeCredentials{username: os.Getenv("GERRIT_USER"), password: os.Getenv("GERRIT_PASSWORD")}
+	default:
+		return forgeCredentials{token: os.Getenv("GITHUB_TOKEN")}
+	}
 }
 
-func getRepoToStudy() *Repository {
-	ownerName := flag.String("owner", "", "Specify the owner name")
-	repoName := flag.String("repo", "", "Specify the repo name")
-	flag.Parse()
+// getPullRequestsInRange returns the pull requests merged in [since, until). Forges only
+// support filtering from a starting point, so the upper bound is applied client-side.
+func getPullRequestsInRange(ctx context.Context, forge Forge, repo *Repository, since, until time.Time) []*PullRequest {
+	pullRequests, err := forge.ListMergedPullRequests(ctx, repo, since)
+	if err != nil {
+		log.Fatal("Encounted error!", err)
+	}
 
-	if *ownerName == "" || *repoName == "" {
-		log.Fatal("Please specify an owner and repo!")
+	inRange := make([]*PullRequest, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		if pr.MergedAt.Before(until) {
+			inRange = append(inRange, pr)
+		}
 	}
 
-	return &Repository{owner: *ownerName, name: *repoName}
+	return inRange
 }
 
-func getPullRequestsFromLastTwoWeeks(ctx context.Context, ghClient *github.Client, owner *string, repo *string) []*github.PullRequest {
-	var allPullRequests []*github.PullRequest
+func main() {
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal("Error in loading .env file!")
+	}
+
+	cfg := parseFlags()
+
+	ctx := context.Background()
+	httpClient := setupHttpClient(cfg.forge, cfg.cacheDir)
+
+	repo := &Repository{owner: cfg.owner, name: cfg.repo}
+	forge := newForge(ctx, cfg.forge, httpClient, cfg.forgeURL, forgeEnvCredentials(cfg.forge))
 
-	opt := &github.PullRequestListOptions{
-		State:       "closed",
-		ListOptions: github.ListOptions{PerPage: 10},
+	rollbackPattern, err := regexp.Compile(cfg.rollbackPattern)
+	if err != nil {
+		log.Fatal("Invalid -rollback-pattern: ", err)
 	}
 
-	now := time.Now()
+	until := cfg.until
+	if until.IsZero() {
+		until = time.Now()
+	}
 
-	for {
-		pullRequests, resp, err := ghClient.PullRequests.List(
-			ctx,
-			*owner,
-			*repo,
-			opt)
+	reporter, err := newReporter(cfg.output)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		if err != nil {
-			log.Fatal("Encounted error!", err)
+	out, closeOut := openOutput(cfg.outputFile)
+	defer closeOut()
+
+	newService := func(bucketSince, bucketUntil time.Time) MetricsService {
+		return MetricsService{
+			forge:           forge,
+			repository:      repo,
+			workers:         cfg.workers,
+			since:           bucketSince,
+			until:           bucketUntil,
+			window:          bucketUntil.Sub(bucketSince),
+			rollbackPattern: rollbackPattern,
+			rollbackWindow:  cfg.rollbackWindow,
+			issueLinker:     newIssueLinker(cfg),
 		}
+	}
 
-		for _, p := range pullRequests {
-			if p.GetMergedAt().IsZero() {
-				continue
-			}
-			weeksAgo := now.Sub(p.MergedAt.Time).Hours() / (24 * 7)
-			if weeksAgo >= 2 {
-				return allPullRequests
-			}
+	if cfg.trend {
+		fmt.Println("Analyzing trend...")
+		points := make([]TrendPoint, 0, cfg.trendBuckets)
 
-			allPullRequests = append(allPullRequests, p)
+		bucketUntil := until
+		for i := 0; i < cfg.trendBuckets; i++ {
+			bucketSince := bucketUntil.Add(-cfg.trendBucketWindow)
+
+			// Pull requests and deployments are both fetched and analyzed per bucket, so
+			// DORA metrics actually vary across points instead of repeating the same
+			// un-windowed totals for every bucket.
+			pullRequests := getPullRequestsInRange(ctx, forge, repo, bucketSince, bucketUntil)
+			service := newService(bucketSince, bucketUntil)
+			metrics := service.AnalyzePullRequests(ctx, pullRequests)
+
+			points = append(points, TrendPoint{Since: bucketSince, Until: bucketUntil, Metrics: metrics})
+			bucketUntil = bucketSince
 		}
 
-		if resp.NextPage == 0 {
-			break
+		// points was built newest-first; reverse it so reporters render oldest-first.
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
 		}
 
-		opt.Page = resp.NextPage
+		if err := reporter.ReportTrend(out, points); err != nil {
+			log.Fatal("Failed to write report: ", err)
+		}
+		return
 	}
 
-	return allPullRequests
-}
-
-func main() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error in loading .env file!")
+	since := cfg.since
+	if since.IsZero() {
+		since = until.Add(-cfg.window)
 	}
 
-	ctx := context.Background()
-	httpClient := setupHttpClient()
-	ghClient := github.NewClient(httpClient)
-
-	repo := getRepoToStudy()
 	fmt.Println("Getting pull requests...")
-	pullRequests := getPullRequestsFromLastTwoWeeks(ctx, ghClient, &repo.owner, &repo.name)
+	pullRequests := getPullRequestsInRange(ctx, forge, repo, since, until)
 
-	service := MetricsService{
-		ghClient:   ghClient,
-		repository: repo,
-	}
+	service := newService(since, until)
 	fmt.Println("Analyzing pull requests...")
 	metrics := service.AnalyzePullRequests(ctx, pullRequests)
 
-	fmt.Println("-------")
-	fmt.Println("METRICS")
-	fmt.Println("-------")
-	fmt.Println()
-	fmt.Printf("Total pull requests:\t\t\t\t%d\n", metrics.TotalPullRequests)
-	fmt.Printf("Untracked pull requests:\t\t\t%d\n", metrics.PullRequestsWithoutIssue)
-	fmt.Printf("Pull requests with reviews:\t\t\t%d\n", metrics.PullRequestsWithReview)
-	fmt.Printf("Review time (average):\t\t\t\t%d hours\n", metrics.AverageReviewTime)
-	fmt.Printf("Review time (median):\t\t\t\t%d hours\n", metrics.MedianReviewTime)
-	fmt.Printf("Time to merge (median):\t\t\t\t%d hours\n", metrics.MedianTimeToMerge)
-	fmt.Printf("Lead time for changes (median):\t\t\t%d hours\n", metrics.MedianLeadTimeForChanges)
+	if err := reporter.Report(out, metrics); err != nil {
+		log.Fatal("Failed to write report: ", err)
+	}
+}
+
+// openOutput returns stdout when outputFile is empty, otherwise a file opened for
+// writing at that path. The returned func must be deferred to close it.
+func openOutput(outputFile string) (*os.File, func()) {
+	if outputFile == "" {
+		return os.Stdout, func() {}
+	}
+
+	f, err := os.Create(outputFile)
+	if err != nil {
+		log.Fatal("Failed to open -output-file: ", err)
+	}
 
+	return f, func() { f.Close() }
 }
 
 type Metrics struct {
@@ -132,4 +216,11 @@ type Metrics struct {
 	TotalPullRequests        int
 	PullRequestsWithoutIssue int
 	PullRequestsWithReview   int
+
+	// DORA holds the remaining DORA metrics, one entry per deployment environment.
+	DORA []DORAMetrics
+
+	// PullRequests holds the per-pull-request statistics the aggregates above were
+	// computed from, for reporters that want row-level detail (e.g. CSV).
+	PullRequests []*pullRequestStatistics
 }
@@ -4,14 +4,16 @@ import (
 	"context"
 	"log"
 	"sort"
-	"strings"
 	"time"
 
-	"github.com/google/go-github/v52/github"
 	"github.com/montanaflynn/stats"
+	"golang.org/x/sync/errgroup"
 )
 
-const branchPrefixNoIssue = "noticket"
+// defaultWorkerCount bounds how many pull requests are processed concurrently when no
+// explicit worker count is configured.
+const defaultWorkerCount = 5
+
 const eventReviewRequested = "review_requested"
 const eventReviewed = "reviewed"
 const pullRequestStateClosed = "closed"
@@ -19,14 +21,43 @@ const pullRequestStateClosed = "closed"
 type MetricsService service
 
 // Performs analysis on the given pull requests and returns the calculated metrics
-func (s *MetricsService) AnalyzePullRequests(ctx context.Context, prs []*github.PullRequest) *Metrics {
-	deployments, _, _ := s.ghClient.Repositories.ListDeployments(ctx, s.repository.owner, s.repository.name, &github.DeploymentsListOptions{})
+func (s *MetricsService) AnalyzePullRequests(ctx context.Context, prs []*PullRequest) *Metrics {
+	deployments, _ := s.forge.ListDeployments(ctx, s.repository, s.since, s.until)
 	deployTimesForPullRequests := s.getDeploymentTimesForSHA(deployments, prs)
 
-	var prStats []*pullRequestStatistics
+	statsPerPullRequest := make([]*pullRequestStatistics, len(prs))
+	workers := s.workers
+	if workers <= 0 {
+		workers = defaultWorkerCount
+	}
+	semaphore := make(chan struct{}, workers)
+
+	g, gctx := errgroup.WithContext(ctx)
+	for i, pr := range prs {
+		i, pr := i, pr
+		g.Go(func() error {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			stat, err := s.processPullRequest(gctx, pr, deployTimesForPullRequests)
+			if err != nil {
+				log.Printf("Skipping pull request #%d: %v", pr.Number, err)
+				return nil
+			}
 
-	for _, pr := range prs {
-		prStats = append(prStats, s.processPullRequest(ctx, pr, deployTimesForPullRequests))
+			statsPerPullRequest[i] = stat
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		log.Fatal("Encounted error!", err)
+	}
+
+	var prStats []*pullRequestStatistics
+	for _, stat := range statsPerPullRequest {
+		if stat != nil {
+			prStats = append(prStats, stat)
+		}
 	}
 
 	untrackedPullRequests := 0
@@ -60,36 +91,46 @@ func (s *MetricsService) AnalyzePullRequests(ctx context.Context, prs []*github.
 	medianTimeToMerge, _ := stats.Median(timeToMerge)
 	medianLeadTimeForChanges, _ := stats.Median(leadTimeForChanges)
 
+	averageReviewTime := 0
+	if reviewedPullRequests > 0 {
+		averageReviewTime = totalReviewTime / reviewedPullRequests
+	}
+
 	return &Metrics{
-		AverageReviewTime:        totalReviewTime / reviewedPullRequests,
+		AverageReviewTime:        averageReviewTime,
 		MedianReviewTime:         int(medianReviewTime),
 		MedianTimeToMerge:        int(medianTimeToMerge),
 		MedianLeadTimeForChanges: int(medianLeadTimeForChanges),
 		TotalPullRequests:        numberOfPullRequests,
 		PullRequestsWithoutIssue: untrackedPullRequests,
 		PullRequestsWithReview:   reviewedPullRequests,
+		DORA:                     s.computeDORAMetrics(deployments, prs),
+		PullRequests:             prStats,
 	}
 
 }
 
-func (s *MetricsService) processPullRequest(ctx context.Context, pr *github.PullRequest, deployTimesForPullRequests map[string]time.Time) *pullRequestStatistics {
-	timelineEvents := s.getAllTimelineEventsForPullRequest(ctx, pr)
+func (s *MetricsService) processPullRequest(ctx context.Context, pr *PullRequest, deployTimesForPullRequests map[string]time.Time) (*pullRequestStatistics, error) {
+	timelineEvents, err := s.getAllTimelineEventsForPullRequest(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
 
 	// Default to creation date in case no review was requested
-	timeReviewRequested := pr.GetCreatedAt().Time
+	timeReviewRequested := pr.CreatedAt
 	isFirstReview := true
 	wasReviewed := false
 
 	var timeReviewed time.Time
 
 	for _, e := range timelineEvents {
-		if isFirstReview && e.GetEvent() == eventReviewRequested {
-			timeReviewRequested = e.GetCreatedAt().Time
+		if isFirstReview && e.Event == eventReviewRequested {
+			timeReviewRequested = e.CreatedAt
 			isFirstReview = false
 		}
 
-		if timeReviewed.IsZero() && e.GetEvent() == eventReviewed {
-			timeReviewed = e.GetSubmittedAt().Time
+		if timeReviewed.IsZero() && e.Event == eventReviewed {
+			timeReviewed = e.SubmittedAt
 			wasReviewed = true
 
 			// We have identified the first review, we know enough
@@ -97,58 +138,46 @@ func (s *MetricsService) processPullRequest(ctx context.Context, pr *github.Pull
 		}
 	}
 
-	timeMerged := pr.GetMergedAt()
+	timeMerged := pr.MergedAt
 
 	var timeDeployed time.Time
-	if value, ok := deployTimesForPullRequests[pr.GetMergeCommitSHA()]; ok {
+	if value, ok := deployTimesForPullRequests[pr.MergeCommitSHA]; ok {
 		timeDeployed = value
 	}
 
+	issueID, isTrackedWithIssue := s.issueLinker.Resolve(ctx, pr)
+
 	return &pullRequestStatistics{
-		IsTrackedWithIssue:    s.doesPullRequestHaveIssueAttached(pr),
+		Number:                pr.Number,
+		Title:                 pr.Title,
+		IsTrackedWithIssue:    isTrackedWithIssue,
+		IssueID:               issueID,
 		TimeToReview:          timeReviewed.Sub(timeReviewRequested).Round(time.Hour),
 		TimeToMerge:           timeMerged.Sub(timeReviewRequested).Round(time.Hour),
 		TimeToProduction:      timeDeployed.Sub(timeReviewRequested).Round(time.Hour),
-		WasClosedWithoutMerge: pr.GetState() == pullRequestStateClosed && !pr.GetMerged(),
+		WasClosedWithoutMerge: pr.State == pullRequestStateClosed && !pr.Merged,
 		WasReviewed:           wasReviewed,
 		WasDeployed:           !timeDeployed.IsZero(),
-	}
+	}, nil
 }
 
 // Returns all the timeline events for a pull request, e.g. review_requested.
-func (s *MetricsService) getAllTimelineEventsForPullRequest(ctx context.Context, pr *github.PullRequest) []*github.Timeline {
-	var allEvents []*github.Timeline
-
-	opt := &github.ListOptions{
-		PerPage: 10,
-	}
-
-	for {
-		timeline, resp, err := s.ghClient.Issues.ListIssueTimeline(ctx, s.repository.owner, s.repository.name, *pr.Number, opt)
-
-		if err != nil {
-			log.Fatal("Encounted error!", err)
-		}
-
-		allEvents = append(allEvents, timeline...)
-
-		if resp.NextPage == 0 {
-			break
-		}
-
-		opt.Page = resp.NextPage
+func (s *MetricsService) getAllTimelineEventsForPullRequest(ctx context.Context, pr *PullRequest) ([]*TimelineEvent, error) {
+	allEvents, err := s.forge.ListTimelineEvents(ctx, s.repository, pr)
+	if err != nil {
+		return nil, err
 	}
 
 	// Sort by time desc
 	sort.Slice(allEvents, func(i, j int) bool {
-		timeA := allEvents[i].GetCreatedAt().Time
+		timeA := allEvents[i].CreatedAt
 		if timeA.IsZero() {
 			// Needed for reviews
-			timeA = allEvents[i].GetSubmittedAt().Time
+			timeA = allEvents[i].SubmittedAt
 		}
-		timeB := allEvents[j].GetCreatedAt().Time
+		timeB := allEvents[j].CreatedAt
 		if timeB.IsZero() {
-			timeB = allEvents[j].GetSubmittedAt().Time
+			timeB = allEvents[j].SubmittedAt
 		}
 
 		// Note that there's still going to be some zero times,
@@ -156,26 +185,25 @@ func (s *MetricsService) getAllTimelineEventsForPullRequest(ctx context.Context,
 		return timeA.Sub(timeB).Hours() > 0
 	})
 
-	return allEvents
+	return allEvents, nil
 }
 
 // Returns a map with as key the SHA of each pull request, and as value the time when that pull
 // request was deployed
 //
 // Pull requests that were not deployed are not included in the map.
-func (s *MetricsService) getDeploymentTimesForSHA(deployments []*github.Deployment, pullRequests []*github.PullRequest) map[string]time.Time {
+func (s *MetricsService) getDeploymentTimesForSHA(deployments []*Deployment, pullRequests []*PullRequest) map[string]time.Time {
 	timeDeployment := make(map[string]time.Time)
 	deploymentShas := make(map[string]time.Time)
 
 	for _, d := range deployments {
-		sha := d.GetSHA()
-		deploymentShas[sha] = d.GetCreatedAt().Time
+		deploymentShas[d.SHA] = d.CreatedAt
 	}
 
 	var timeForThisDeployment time.Time
 
 	for _, pr := range pullRequests {
-		sha := pr.GetMergeCommitSHA()
+		sha := pr.MergeCommitSHA
 		if value, ok := deploymentShas[sha]; ok {
 			timeForThisDeployment = value
 			// Do not save these, they will be deployed quickly
@@ -192,15 +220,10 @@ func (s *MetricsService) getDeploymentTimesForSHA(deployments []*github.Deployme
 	return timeDeployment
 }
 
-// Returns true if this pull request has a branch name that indicates being linked
-// to an issue
-func (s *MetricsService) doesPullRequestHaveIssueAttached(pr *github.PullRequest) bool {
-	branch := *pr.Head.Label
-	isTrackedWithIssue := !strings.Contains(branch, branchPrefixNoIssue)
-	return isTrackedWithIssue
-}
-
 type pullRequestStatistics struct {
+	Number int
+	Title  string
+
 	// Time from ready for review (defined as first review request) till first actual review
 	TimeToReview time.Duration
 
@@ -208,8 +231,15 @@ type pullRequestStatistics struct {
 	TimeToMerge time.Duration
 
 	// Time from review first requested and PR appearing in production
-	TimeToProduction      time.Duration
-	IsTrackedWithIssue    bool
+	TimeToProduction time.Duration
+
+	IsTrackedWithIssue bool
+
+	// IssueID is the external ticket resolved by the configured IssueLinker, e.g. a Jira
+	// key or a GitHub issue number. Empty when IsTrackedWithIssue is true but no concrete
+	// ticket could be resolved (the branch-name fallback).
+	IssueID string
+
 	WasClosedWithoutMerge bool
 	WasReviewed           bool
 	WasDeployed           bool
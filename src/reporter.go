@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputCSV  = "csv"
+	outputProm = "prom"
+	outputHTML = "html"
+)
+
+// TrendPoint is the metrics for a single bucket of a -trend run.
+type TrendPoint struct {
+	Since   time.Time
+	Until   time.Time
+	Metrics *Metrics
+}
+
+// Reporter renders a set of Metrics, or a historical trend series of them, to w in a
+// specific format.
+type Reporter interface {
+	Report(w io.Writer, metrics *Metrics) error
+	ReportTrend(w io.Writer, points []TrendPoint) error
+}
+
+// newReporter builds the Reporter selected via -output.
+func newReporter(output string) (Reporter, error) {
+	switch output {
+	case outputText, "":
+		return &textReporter{}, nil
+	case outputJSON:
+		return &jsonReporter{}, nil
+	case outputCSV:
+		return &csvReporter{}, nil
+	case outputProm:
+		return &promReporter{}, nil
+	case outputHTML:
+		return &htmlReporter{}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q, expected one of: text, json, csv, prom, html", output)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+// csvReporter writes a single table, one row per pull request, so the output stays a plain
+// CSV that pandas.read_csv, Excel and similar tools can parse directly. The run-level
+// aggregate and DORA metrics don't fit that per-PR shape, so they're left to the JSON and
+// Prometheus reporters, which already carry them in full.
+type csvReporter struct{}
+
+func (r *csvReporter) Report(w io.Writer, metrics *Metrics) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := []string{
+		"number", "title", "issue_id", "is_tracked_with_issue",
+		"time_to_review_hours", "time_to_merge_hours", "time_to_production_hours",
+		"was_reviewed", "was_deployed", "was_closed_without_merge",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, stat := range metrics.PullRequests {
+		row := []string{
+			strconv.Itoa(stat.Number),
+			stat.Title,
+			stat.IssueID,
+			strconv.FormatBool(stat.IsTrackedWithIssue),
+			strconv.FormatFloat(stat.TimeToReview.Hours(), 'f', -1, 64),
+			strconv.FormatFloat(stat.TimeToMerge.Hours(), 'f', -1, 64),
+			strconv.FormatFloat(stat.TimeToProduction.Hours(), 'f', -1, 64),
+			strconv.FormatBool(stat.WasReviewed),
+			strconv.FormatBool(stat.WasDeployed),
+			strconv.FormatBool(stat.WasClosedWithoutMerge),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReportTrend writes one row per bucket with that bucket's aggregate metrics; per-PR
+// detail is only meaningful for a single run, not a time series.
+func (r *csvReporter) ReportTrend(w io.Writer, points []TrendPoint) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := []string{
+		"since", "until", "total_pull_requests", "pull_requests_without_issue",
+		"pull_requests_with_review", "average_review_time_hours", "median_review_time_hours",
+		"median_time_to_merge_hours", "median_lead_time_for_changes_hours",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, point := range points {
+		m := point.Metrics
+		row := []string{
+			point.Since.Format(time.RFC3339),
+			point.Until.Format(time.RFC3339),
+			strconv.Itoa(m.TotalPullRequests),
+			strconv.Itoa(m.PullRequestsWithoutIssue),
+			strconv.Itoa(m.PullRequestsWithReview),
+			strconv.Itoa(m.AverageReviewTime),
+			strconv.Itoa(m.MedianReviewTime),
+			strconv.Itoa(m.MedianTimeToMerge),
+			strconv.Itoa(m.MedianLeadTimeForChanges),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"html/template"
+	"io"
+	"strconv"
+)
+
+// histogramBucketHours is the width of each bucket in the distribution charts.
+const histogramBucketHours = 24
+
+// histogramBucketCount is how many buckets each distribution chart has before everything
+// slower is folded into a final overflow bucket.
+const histogramBucketCount = 8
+
+// htmlReporter renders a static HTML dashboard with bar charts of the review, merge and
+// deploy time distributions, suitable for saving as a CI artifact.
+type htmlReporter struct{}
+
+type htmlChart struct {
+	Title   string
+	Buckets []htmlBucket
+}
+
+type htmlBucket struct {
+	Label       string
+	Count       int
+	HeightRatio float64
+}
+
+type htmlReportData struct {
+	Metrics *Metrics
+	Charts  []htmlChart
+}
+
+func (r *htmlReporter) Report(w io.Writer, metrics *Metrics) error {
+	reviewTimes := make([]float64, 0, len(metrics.PullRequests))
+	mergeTimes := make([]float64, 0, len(metrics.PullRequests))
+	deployTimes := make([]float64, 0, len(metrics.PullRequests))
+
+	for _, stat := range metrics.PullRequests {
+		if stat.WasReviewed {
+			reviewTimes = append(reviewTimes, stat.TimeToReview.Hours())
+		}
+		mergeTimes = append(mergeTimes, stat.TimeToMerge.Hours())
+		if stat.WasDeployed {
+			deployTimes = append(deployTimes, stat.TimeToProduction.Hours())
+		}
+	}
+
+	data := htmlReportData{
+		Metrics: metrics,
+		Charts: []htmlChart{
+			buildChart("Time to review", reviewTimes),
+			buildChart("Time to merge", mergeTimes),
+			buildChart("Time to deploy", deployTimes),
+		},
+	}
+
+	return htmlReportTemplate.Execute(w, data)
+}
+
+func buildChart(title string, hours []float64) htmlChart {
+	buckets := make([]int, histogramBucketCount+1)
+
+	for _, h := range hours {
+		index := int(h / histogramBucketHours)
+		if index > histogramBucketCount {
+			index = histogramBucketCount
+		}
+		buckets[index]++
+	}
+
+	maxCount := 0
+	for _, count := range buckets {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	chart := htmlChart{Title: title}
+	for i, count := range buckets {
+		label := strconv.Itoa(i*histogramBucketHours) + "-" + strconv.Itoa((i+1)*histogramBucketHours) + "h"
+		if i == histogramBucketCount {
+			label = strconv.Itoa(i*histogramBucketHours) + "h+"
+		}
+
+		ratio := 0.0
+		if maxCount > 0 {
+			ratio = float64(count) / float64(maxCount)
+		}
+
+		chart.Buckets = append(chart.Buckets, htmlBucket{Label: label, Count: count, HeightRatio: ratio})
+	}
+
+	return chart
+}
+
+type htmlTrendPoint struct {
+	Label                    string
+	MedianTimeToMerge        int
+	MedianLeadTimeForChanges int
+}
+
+// ReportTrend renders one row per bucket and a bar chart of the median time to merge
+// across buckets, so a regression is visible at a glance.
+func (r *htmlReporter) ReportTrend(w io.Writer, points []TrendPoint) error {
+	rows := make([]htmlTrendPoint, 0, len(points))
+	chart := htmlChart{Title: "Median time to merge per bucket"}
+
+	maxCount := 0
+	for _, point := range points {
+		if point.Metrics.MedianTimeToMerge > maxCount {
+			maxCount = point.Metrics.MedianTimeToMerge
+		}
+	}
+
+	for _, point := range points {
+		label := point.Since.Format("2006-01-02") + " to " + point.Until.Format("2006-01-02")
+
+		rows = append(rows, htmlTrendPoint{
+			Label:                    label,
+			MedianTimeToMerge:        point.Metrics.MedianTimeToMerge,
+			MedianLeadTimeForChanges: point.Metrics.MedianLeadTimeForChanges,
+		})
+
+		ratio := 0.0
+		if maxCount > 0 {
+			ratio = float64(point.Metrics.MedianTimeToMerge) / float64(maxCount)
+		}
+		chart.Buckets = append(chart.Buckets, htmlBucket{
+			Label:       label,
+			Count:       point.Metrics.MedianTimeToMerge,
+			HeightRatio: ratio,
+		})
+	}
+
+	data := struct {
+		Points []htmlTrendPoint
+		Chart  htmlChart
+	}{Points: rows, Chart: chart}
+
+	return htmlTrendTemplate.Execute(w, data)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"mul": func(a, b float64) float64 { return a * b },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>boots report</title>
+<style>
+  body { font-family: sans-serif; margin: 2rem; }
+  .summary { display: flex; gap: 2rem; flex-wrap: wrap; margin-bottom: 2rem; }
+  .stat { border: 1px solid #ddd; border-radius: 6px; padding: 1rem; min-width: 10rem; }
+  .stat .value { font-size: 1.5rem; font-weight: bold; }
+  .chart { margin-bottom: 2rem; }
+  .bars { display: flex; align-items: flex-end; gap: 4px; height: 150px; }
+  .bar { background: #4078c0; width: 40px; position: relative; }
+  .bar span { position: absolute; bottom: -1.2rem; left: 0; right: 0; text-align: center; font-size: 0.7rem; }
+</style>
+</head>
+<body>
+<h1>boots report</h1>
+
+<div class="summary">
+  <div class="stat"><div>Total pull requests</div><div class="value">{{.Metrics.TotalPullRequests}}</div></div>
+  <div class="stat"><div>Without issue</div><div class="value">{{.Metrics.PullRequestsWithoutIssue}}</div></div>
+  <div class="stat"><div>With review</div><div class="value">{{.Metrics.PullRequestsWithReview}}</div></div>
+  <div class="stat"><div>Median time to merge</div><div class="value">{{.Metrics.MedianTimeToMerge}}h</div></div>
+  <div class="stat"><div>Median lead time for changes</div><div class="value">{{.Metrics.MedianLeadTimeForChanges}}h</div></div>
+</div>
+
+{{range .Charts}}
+<div class="chart">
+  <h2>{{.Title}}</h2>
+  <div class="bars">
+  {{range .Buckets}}
+    <div class="bar" style="height: {{printf "%.0f" (mul .HeightRatio 150.0)}}px">
+      <span>{{.Label}} ({{.Count}})</span>
+    </div>
+  {{end}}
+  </div>
+</div>
+{{end}}
+
+{{if .Metrics.DORA}}
+<h2>DORA metrics</h2>
+<table border="1" cellpadding="6" cellspacing="0">
+  <tr><th>Environment</th><th>Deployment frequency (/day)</th><th>Change failure rate</th><th>MTTR</th></tr>
+  {{range .Metrics.DORA}}
+  <tr><td>{{.Environment}}</td><td>{{printf "%.2f" .DeploymentFrequency}}</td><td>{{printf "%.1f" (mul .ChangeFailureRate 100.0)}}%</td><td>{{.MTTR}}</td></tr>
+  {{end}}
+</table>
+{{end}}
+
+</body>
+</html>
+`))
+
+var htmlTrendTemplate = template.Must(template.New("trend").Funcs(template.FuncMap{
+	"mul": func(a, b float64) float64 { return a * b },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>boots trend report</title>
+<style>
+  body { font-family: sans-serif; margin: 2rem; }
+  table { border-collapse: collapse; margin-bottom: 2rem; }
+  th, td { border: 1px solid #ddd; padding: 0.4rem 0.8rem; text-align: right; }
+  th:first-child, td:first-child { text-align: left; }
+  .chart { margin-bottom: 2rem; }
+  .bars { display: flex; align-items: flex-end; gap: 4px; height: 150px; }
+  .bar { background: #4078c0; width: 40px; position: relative; }
+  .bar span { position: absolute; bottom: -1.2rem; left: 0; right: 0; text-align: center; font-size: 0.7rem; white-space: nowrap; }
+</style>
+</head>
+<body>
+<h1>boots trend report</h1>
+
+<div class="chart">
+  <h2>{{.Chart.Title}}</h2>
+  <div class="bars">
+  {{range .Chart.Buckets}}
+    <div class="bar" style="height: {{printf "%.0f" (mul .HeightRatio 150.0)}}px">
+      <span>{{.Label}} ({{.Count}}h)</span>
+    </div>
+  {{end}}
+  </div>
+</div>
+
+<table>
+  <tr><th>Window</th><th>Median time to merge (h)</th><th>Median lead time for changes (h)</th></tr>
+  {{range .Points}}
+  <tr><td>{{.Label}}</td><td>{{.MedianTimeToMerge}}</td><td>{{.MedianLeadTimeForChanges}}</td></tr>
+  {{end}}
+</table>
+
+</body>
+</html>
+`))
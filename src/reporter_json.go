@@ -0,0 +1,22 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonReporter writes the full Metrics struct, including per-pull-request detail, as
+// indented JSON.
+type jsonReporter struct{}
+
+func (r *jsonReporter) Report(w io.Writer, metrics *Metrics) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(metrics)
+}
+
+func (r *jsonReporter) ReportTrend(w io.Writer, points []TrendPoint) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(points)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// promReporter writes metrics in the Prometheus textfile exposition format, so they can be
+// picked up by node_exporter's textfile collector or pushed via Pushgateway.
+type promReporter struct{}
+
+func (r *promReporter) Report(w io.Writer, metrics *Metrics) error {
+	writeMetricGauges(w, metrics, 0)
+	return nil
+}
+
+// simpleGauge describes one scalar metric pulled straight off Metrics.
+type simpleGauge struct {
+	name string
+	help string
+	val  func(*Metrics) float64
+}
+
+var simpleGauges = []simpleGauge{
+	{"boots_pull_requests_total", "Total merged pull requests analyzed", func(m *Metrics) float64 { return float64(m.TotalPullRequests) }},
+	{"boots_pull_requests_without_issue", "Pull requests without a linked issue", func(m *Metrics) float64 { return float64(m.PullRequestsWithoutIssue) }},
+	{"boots_pull_requests_with_review", "Pull requests that received a review", func(m *Metrics) float64 { return float64(m.PullRequestsWithReview) }},
+	{"boots_review_time_average_hours", "Average time to first review, in hours", func(m *Metrics) float64 { return float64(m.AverageReviewTime) }},
+	{"boots_review_time_median_hours", "Median time to first review, in hours", func(m *Metrics) float64 { return float64(m.MedianReviewTime) }},
+	{"boots_time_to_merge_median_hours", "Median time to merge, in hours", func(m *Metrics) float64 { return float64(m.MedianTimeToMerge) }},
+	{"boots_lead_time_for_changes_median_hours", "Median lead time for changes, in hours", func(m *Metrics) float64 { return float64(m.MedianLeadTimeForChanges) }},
+}
+
+// doraGauge describes one DORA metric, which is additionally broken down by environment.
+type doraGauge struct {
+	name string
+	help string
+	val  func(DORAMetrics) float64
+}
+
+var doraGauges = []doraGauge{
+	{"boots_deployment_frequency_per_day", "Successful deployments per day", func(d DORAMetrics) float64 { return d.DeploymentFrequency }},
+	{"boots_change_failure_rate", "Fraction of deployments that were rolled back", func(d DORAMetrics) float64 { return d.ChangeFailureRate }},
+	{"boots_mttr_hours", "Median time to recover from a failed deployment, in hours", func(d DORAMetrics) float64 { return d.MTTR.Hours() }},
+}
+
+func writeMetricGauges(w io.Writer, metrics *Metrics, timestampMillis int64) {
+	for _, g := range simpleGauges {
+		fmt.Fprintf(w, "# HELP %s %s\n", g.name, g.help)
+		fmt.Fprintf(w, "# TYPE %s gauge\n", g.name)
+		writeGaugeLine(w, g.name, "", g.val(metrics), timestampMillis)
+	}
+
+	for _, g := range doraGauges {
+		if len(metrics.DORA) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "# HELP %s %s\n", g.name, g.help)
+		fmt.Fprintf(w, "# TYPE %s gauge\n", g.name)
+		for _, d := range metrics.DORA {
+			writeGaugeLine(w, g.name, fmt.Sprintf("{environment=%q}", d.Environment), g.val(d), timestampMillis)
+		}
+	}
+}
+
+// ReportTrend writes each bucket's gauges with an explicit timestamp (the bucket's end, in
+// milliseconds) per the exposition format. Unlike Report, samples for a single metric name
+// must stay contiguous across every bucket, per the text exposition format's "one group per
+// metric name" rule, so this groups by metric first and buckets second rather than
+// delegating to writeMetricGauges per bucket.
+func (r *promReporter) ReportTrend(w io.Writer, points []TrendPoint) error {
+	for _, g := range simpleGauges {
+		fmt.Fprintf(w, "# HELP %s %s\n", g.name, g.help)
+		fmt.Fprintf(w, "# TYPE %s gauge\n", g.name)
+		for _, point := range points {
+			writeGaugeLine(w, g.name, "", g.val(point.Metrics), point.Until.UnixMilli())
+		}
+	}
+
+	for _, g := range doraGauges {
+		wroteHeader := false
+		for _, point := range points {
+			for _, d := range point.Metrics.DORA {
+				if !wroteHeader {
+					fmt.Fprintf(w, "# HELP %s %s\n", g.name, g.help)
+					fmt.Fprintf(w, "# TYPE %s gauge\n", g.name)
+					wroteHeader = true
+				}
+
+				writeGaugeLine(w, g.name, fmt.Sprintf("{environment=%q}", d.Environment), g.val(d), point.Until.UnixMilli())
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeGaugeLine(w io.Writer, name, labels string, value float64, timestampMillis int64) {
+	if timestampMillis == 0 {
+		fmt.Fprintf(w, "%s%s %v\n", name, labels, value)
+		return
+	}
+
+	fmt.Fprintf(w, "%s%s %v %d\n", name, labels, value, timestampMillis)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// textReporter prints the same human-readable summary boots has always printed to stdout.
+type textReporter struct{}
+
+func (r *textReporter) Report(w io.Writer, metrics *Metrics) error {
+	fmt.Fprintln(w, "-------")
+	fmt.Fprintln(w, "METRICS")
+	fmt.Fprintln(w, "-------")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Total pull requests:\t\t\t\t%d\n", metrics.TotalPullRequests)
+	fmt.Fprintf(w, "Untracked pull requests:\t\t\t%d\n", metrics.PullRequestsWithoutIssue)
+	fmt.Fprintf(w, "Pull requests with reviews:\t\t\t%d\n", metrics.PullRequestsWithReview)
+	fmt.Fprintf(w, "Review time (average):\t\t\t\t%d hours\n", metrics.AverageReviewTime)
+	fmt.Fprintf(w, "Review time (median):\t\t\t\t%d hours\n", metrics.MedianReviewTime)
+	fmt.Fprintf(w, "Time to merge (median):\t\t\t\t%d hours\n", metrics.MedianTimeToMerge)
+	fmt.Fprintf(w, "Lead time for changes (median):\t\t\t%d hours\n", metrics.MedianLeadTimeForChanges)
+
+	for _, d := range metrics.DORA {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Environment: %s\n", d.Environment)
+		fmt.Fprintf(w, "Deployment frequency:\t\t\t\t%.2f/day\n", d.DeploymentFrequency)
+		fmt.Fprintf(w, "Change failure rate:\t\t\t\t%.1f%%\n", d.ChangeFailureRate*100)
+		fmt.Fprintf(w, "MTTR:\t\t\t\t\t\t%s\n", d.MTTR)
+	}
+
+	return nil
+}
+
+func (r *textReporter) ReportTrend(w io.Writer, points []TrendPoint) error {
+	for _, point := range points {
+		fmt.Fprintf(w, "=== %s to %s ===\n", point.Since.Format("2006-01-02"), point.Until.Format("2006-01-02"))
+		if err := r.Report(w, point.Metrics); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}